@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/command"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/maven"
+	"github.com/SAP/jenkins-library/pkg/maven/buildinfo"
+	"github.com/SAP/jenkins-library/pkg/maven/settings"
+	"github.com/SAP/jenkins-library/pkg/nexus"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+)
+
+const (
+	uploadModeREST  = "rest"
+	uploadModeMaven = "maven"
+	uploadModeAuto  = "auto"
+
+	// nexusUploadServerID is the settings.xml <server> id used to carry Nexus credentials
+	// for the maven-based upload path, referenced from the deploy-file goal via -DrepositoryId.
+	nexusUploadServerID = "nexusUpload"
+)
+
+type mavenExecRunner interface {
+	Stdout(out io.Writer)
+	Stderr(err io.Writer)
+	RunExecutable(e string, p ...string) error
+}
+
+// classifierDescription is one entry of the additionalClassifiers parameter, e.g.
+// {"classifier": "sources", "type": "jar"}.
+type classifierDescription struct {
+	Classifier string `json:"classifier"`
+	Type       string `json:"type"`
+}
+
+func nexusUpload(options nexusUploadOptions, telemetryData *telemetry.CustomData) {
+	err := runNexusUpload(options, &command.Command{}, &piperutils.Files{})
+	if err != nil {
+		log.Entry().WithError(err).Fatal("step execution failed")
+	}
+}
+
+type settingsUtils interface {
+	FileExists(filename string) (bool, error)
+	FileRead(filename string) ([]byte, error)
+	FileWrite(filename string, content []byte, perm os.FileMode) error
+}
+
+func runNexusUpload(options nexusUploadOptions, execRunner mavenExecRunner, utils settingsUtils) error {
+	artifacts, err := collectArtifacts(options, execRunner)
+	if err != nil {
+		return fmt.Errorf("failed to determine artifacts to upload: %w", err)
+	}
+
+	mode := options.UploadMode
+	if mode == "" {
+		mode = uploadModeAuto
+	}
+
+	for _, artifact := range artifacts {
+		if err := uploadArtifact(options, artifact, mode, execRunner, utils); err != nil {
+			return fmt.Errorf("failed to upload artifact '%s:%s': %w", artifact.ArtifactID, artifact.Classifier, err)
+		}
+	}
+
+	if options.UploadBuildInfo {
+		if err := uploadBuildInfo(options, execRunner); err != nil {
+			return fmt.Errorf("failed to upload build-info: %w", err)
+		}
+	}
+	return nil
+}
+
+func uploadBuildInfo(options nexusUploadOptions, execRunner mavenExecRunner) error {
+	info, err := buildinfo.Generate(buildinfo.GenerateOptions{
+		Name:    options.BuildInfoName,
+		Number:  options.BuildInfoNumber,
+		PomPath: "pom.xml",
+	}, execRunner)
+	if err != nil {
+		return fmt.Errorf("failed to generate build-info: %w", err)
+	}
+
+	return buildinfo.Publish(info, buildinfo.PublishOptions{
+		URL:      options.BuildInfoURL,
+		Username: options.User,
+		Password: options.Password,
+	})
+}
+
+// uploadArtifact uploads a single artifact according to mode. In "auto" mode, the native
+// Nexus REST API is used unless the artifact is a SNAPSHOT version, since Nexus rejects
+// REST uploads of SNAPSHOTs into hosted snapshot repositories - in that case, and whenever
+// mode is "maven", the existing mvn deploy:deploy-file based upload is used instead.
+func uploadArtifact(options nexusUploadOptions, artifact nexus.Coordinate, mode string, execRunner mavenExecRunner, utils settingsUtils) error {
+	switch mode {
+	case uploadModeREST:
+		if artifact.IsSnapshot() {
+			return fmt.Errorf("uploadMode 'rest' does not support SNAPSHOT version '%s'", artifact.Version)
+		}
+		return uploadViaRest(options, artifact)
+	case uploadModeMaven:
+		return uploadViaMaven(options, artifact, execRunner, utils)
+	case uploadModeAuto:
+		if artifact.IsSnapshot() {
+			log.Entry().Infof("artifact '%s' is a SNAPSHOT version, falling back to maven-based upload", artifact.ArtifactID)
+			return uploadViaMaven(options, artifact, execRunner, utils)
+		}
+		return uploadViaRest(options, artifact)
+	default:
+		return fmt.Errorf("unsupported uploadMode '%s', must be one of 'rest', 'maven', 'auto'", mode)
+	}
+}
+
+func uploadViaRest(options nexusUploadOptions, artifact nexus.Coordinate) error {
+	client := nexus.NewClient(nexus.ClientOptions{
+		Version:    options.Version,
+		BaseURL:    nexusBaseURL(options.Url),
+		Repository: options.Repository,
+		Username:   options.User,
+		Password:   options.Password,
+	})
+	return client.Upload(artifact)
+}
+
+// nexusBaseURL prefixes url with "http://" for backwards compatibility with bare
+// host[:port] values, unless it already carries an explicit http:// or https:// scheme.
+func nexusBaseURL(url string) string {
+	if strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
+		return url
+	}
+	return "http://" + url
+}
+
+func uploadViaMaven(options nexusUploadOptions, artifact nexus.Coordinate, execRunner mavenExecRunner, utils settingsUtils) error {
+	repositoryURL := fmt.Sprintf("%s/content/repositories/%s", nexusBaseURL(options.Url), options.Repository)
+	if options.Version == "nexus3" {
+		repositoryURL = fmt.Sprintf("%s/repository/%s", nexusBaseURL(options.Url), options.Repository)
+	}
+
+	settingsFile, err := buildUploadSettings(options, utils)
+	if err != nil {
+		return fmt.Errorf("failed to prepare maven settings for nexus upload: %w", err)
+	}
+
+	defines := []string{
+		"-Dfile=" + artifact.File,
+		"-DrepositoryId=" + nexusUploadServerID,
+		"-Durl=" + repositoryURL,
+		"-DgroupId=" + artifact.GroupID,
+		"-DartifactId=" + artifact.ArtifactID,
+		"-Dversion=" + artifact.Version,
+		"-Dpackaging=" + artifact.Packaging,
+	}
+	if artifact.Classifier != "" {
+		defines = append(defines, "-Dclassifier="+artifact.Classifier)
+	}
+
+	mavenOptions := maven.ExecuteOptions{
+		Goals:              []string{"org.apache.maven.plugins:maven-deploy-plugin:2.8.2:deploy-file"},
+		Defines:            defines,
+		GlobalSettingsFile: settingsFile,
+		M2Path:             options.M2Path,
+	}
+	_, err = maven.Execute(&mavenOptions, execRunner)
+	return err
+}
+
+// buildUploadSettings injects a <server> entry carrying the Nexus credentials into a copy
+// of options.GlobalSettingsFile (or a minimal settings.xml if none was provided or the file
+// does not exist), written to .pipeline/nexusUploadSettings.xml, so the deploy-file goal can
+// authenticate via -DrepositoryId instead of putting credentials on the Maven command line.
+func buildUploadSettings(options nexusUploadOptions, utils settingsUtils) (string, error) {
+	doc := []byte("<settings></settings>")
+	if options.GlobalSettingsFile != "" {
+		exists, err := utils.FileExists(options.GlobalSettingsFile)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			content, err := utils.FileRead(options.GlobalSettingsFile)
+			if err != nil {
+				return "", err
+			}
+			doc = content
+		}
+	}
+
+	updated, err := settings.AddServerEntry(doc, settings.Server{
+		ID:       nexusUploadServerID,
+		Username: options.User,
+		Password: options.Password,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	const settingsFile = ".pipeline/nexusUploadSettings.xml"
+	if err := utils.FileWrite(settingsFile, updated, 0666); err != nil {
+		return "", err
+	}
+	return settingsFile, nil
+}
+
+// collectArtifacts evaluates the project's pom.xml for the main artifact's coordinates and
+// combines them with the classifiers requested via additionalClassifiers.
+func collectArtifacts(options nexusUploadOptions, execRunner mavenExecRunner) ([]nexus.Coordinate, error) {
+	evaluateOptions := maven.EvaluateOptions{
+		PomPath:            "pom.xml",
+		GlobalSettingsFile: options.GlobalSettingsFile,
+		M2Path:             options.M2Path,
+	}
+
+	groupID := options.GroupID
+	if groupID == "" {
+		value, err := maven.Evaluate(&evaluateOptions, "project.groupId", execRunner)
+		if err != nil {
+			return nil, err
+		}
+		groupID = value
+	}
+
+	artifactID := options.ArtifactID
+	if artifactID == "" {
+		value, err := maven.Evaluate(&evaluateOptions, "project.artifactId", execRunner)
+		if err != nil {
+			return nil, err
+		}
+		artifactID = value
+	}
+
+	version, err := maven.Evaluate(&evaluateOptions, "project.version", execRunner)
+	if err != nil {
+		return nil, err
+	}
+	packaging, err := maven.Evaluate(&evaluateOptions, "project.packaging", execRunner)
+	if err != nil {
+		return nil, err
+	}
+	finalName, err := maven.Evaluate(&evaluateOptions, "project.build.finalName", execRunner)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts := []nexus.Coordinate{
+		{
+			GroupID:    groupID,
+			ArtifactID: artifactID,
+			Version:    version,
+			Packaging:  packaging,
+			File:       "target/" + finalName + "." + packaging,
+		},
+	}
+
+	if options.AdditionalClassifiers != "" {
+		var classifiers []classifierDescription
+		if err := json.Unmarshal([]byte(options.AdditionalClassifiers), &classifiers); err != nil {
+			return nil, fmt.Errorf("failed to parse additionalClassifiers: %w", err)
+		}
+		for _, classifier := range classifiers {
+			artifacts = append(artifacts, nexus.Coordinate{
+				GroupID:    groupID,
+				ArtifactID: artifactID,
+				Version:    version,
+				Packaging:  classifier.Type,
+				Classifier: classifier.Classifier,
+				File:       "target/" + finalName + "-" + classifier.Classifier + "." + classifier.Type,
+			})
+		}
+	}
+
+	return artifacts, nil
+}
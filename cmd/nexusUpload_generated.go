@@ -24,6 +24,11 @@ type nexusUploadOptions struct {
 	AdditionalClassifiers string `json:"additionalClassifiers,omitempty"`
 	User                  string `json:"user,omitempty"`
 	Password              string `json:"password,omitempty"`
+	UploadMode            string `json:"uploadMode,omitempty"`
+	UploadBuildInfo       bool   `json:"uploadBuildInfo,omitempty"`
+	BuildInfoURL          string `json:"buildInfoUrl,omitempty"`
+	BuildInfoName         string `json:"buildInfoName,omitempty"`
+	BuildInfoNumber       string `json:"buildInfoNumber,omitempty"`
 }
 
 // NexusUploadCommand Upload artifacts to Nexus
@@ -63,7 +68,7 @@ func NexusUploadCommand() *cobra.Command {
 
 func addNexusUploadFlags(cmd *cobra.Command, stepConfig *nexusUploadOptions) {
 	cmd.Flags().StringVar(&stepConfig.Version, "version", "nexus3", "The Nexus Repository Manager version. Currently supported are 'nexus2' and 'nexus3'.")
-	cmd.Flags().StringVar(&stepConfig.Url, "url", os.Getenv("PIPER_url"), "URL of the nexus. The scheme part of the URL will not be considered, because only http is supported.")
+	cmd.Flags().StringVar(&stepConfig.Url, "url", os.Getenv("PIPER_url"), "URL of the nexus. May include an explicit http:// or https:// scheme; defaults to http:// if omitted.")
 	cmd.Flags().StringVar(&stepConfig.Repository, "repository", os.Getenv("PIPER_repository"), "Name of the nexus repository.")
 	cmd.Flags().StringVar(&stepConfig.GroupID, "groupId", os.Getenv("PIPER_groupId"), "Group ID of the artifacts. Only used in MTA projects, ignored for Maven.")
 	cmd.Flags().StringVar(&stepConfig.ArtifactID, "artifactId", os.Getenv("PIPER_artifactId"), "The artifact ID used for both the .mtar and mta.yaml files deployed for MTA projects, ignored for Maven.")
@@ -72,6 +77,11 @@ func addNexusUploadFlags(cmd *cobra.Command, stepConfig *nexusUploadOptions) {
 	cmd.Flags().StringVar(&stepConfig.AdditionalClassifiers, "additionalClassifiers", os.Getenv("PIPER_additionalClassifiers"), "List of additional classifiers that should be deployed to nexus. Each item is a map of a type and a classifier name.")
 	cmd.Flags().StringVar(&stepConfig.User, "user", os.Getenv("PIPER_user"), "User")
 	cmd.Flags().StringVar(&stepConfig.Password, "password", os.Getenv("PIPER_password"), "Password")
+	cmd.Flags().StringVar(&stepConfig.UploadMode, "uploadMode", "auto", "The upload mechanism to use: 'rest' uploads directly via the Nexus REST API, 'maven' shells out to 'mvn deploy:deploy-file', 'auto' uses 'rest' and falls back to 'maven' for SNAPSHOT versions.")
+	cmd.Flags().BoolVar(&stepConfig.UploadBuildInfo, "uploadBuildInfo", false, "Whether to generate a build-info document for the build and publish it to buildInfoUrl.")
+	cmd.Flags().StringVar(&stepConfig.BuildInfoURL, "buildInfoUrl", os.Getenv("PIPER_buildInfoUrl"), "The repository manager's build-info upload endpoint, used when uploadBuildInfo is true.")
+	cmd.Flags().StringVar(&stepConfig.BuildInfoName, "buildInfoName", os.Getenv("PIPER_buildInfoName"), "The build name recorded in the build-info document, used when uploadBuildInfo is true.")
+	cmd.Flags().StringVar(&stepConfig.BuildInfoNumber, "buildInfoNumber", os.Getenv("PIPER_buildInfoNumber"), "The build number recorded in the build-info document, used when uploadBuildInfo is true.")
 
 	cmd.MarkFlagRequired("url")
 	cmd.MarkFlagRequired("repository")
@@ -167,6 +177,46 @@ func nexusUploadMetadata() config.StepData {
 						Mandatory:   false,
 						Aliases:     []config.Alias{},
 					},
+					{
+						Name:        "uploadMode",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{{Name: "nexus/uploadMode"}},
+					},
+					{
+						Name:        "uploadBuildInfo",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{{Name: "nexus/uploadBuildInfo"}},
+					},
+					{
+						Name:        "buildInfoUrl",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{{Name: "nexus/buildInfoUrl"}},
+					},
+					{
+						Name:        "buildInfoName",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{{Name: "nexus/buildInfoName"}},
+					},
+					{
+						Name:        "buildInfoNumber",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{{Name: "nexus/buildInfoNumber"}},
+					},
 				},
 			},
 		},
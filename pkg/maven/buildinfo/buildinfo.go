@@ -0,0 +1,225 @@
+// Package buildinfo generates a JSON build-info document for a Maven build, analogous to
+// the extractor listener bridged in by jfrog's build-info-maven-plugin: it records which
+// artifacts a build produced and which dependencies it resolved, so downstream release and
+// promotion steps have a machine-readable provenance record for every Maven build the
+// library drives.
+package buildinfo
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar"
+)
+
+// BuildInfo is the top-level JSON build-info document for a single Maven invocation.
+type BuildInfo struct {
+	Name    string   `json:"name"`
+	Number  string   `json:"number"`
+	Started string   `json:"started"`
+	Modules []Module `json:"modules"`
+}
+
+// Module is the build-info record for a single Maven module (one pom.xml).
+type Module struct {
+	ID           string       `json:"id"`
+	Artifacts    []Artifact   `json:"artifacts"`
+	Dependencies []Dependency `json:"dependencies"`
+}
+
+// Artifact is a single file produced by a module, identified by its checksums.
+type Artifact struct {
+	Name   string `json:"name"`
+	SHA1   string `json:"sha1"`
+	SHA256 string `json:"sha256"`
+	MD5    string `json:"md5"`
+}
+
+// Dependency is a single dependency a module was resolved against.
+type Dependency struct {
+	ID     string `json:"id"`
+	Scope  string `json:"scope"`
+	SHA1   string `json:"sha1"`
+	SHA256 string `json:"sha256"`
+	MD5    string `json:"md5"`
+}
+
+type mavenExecRunner interface {
+	Stdout(out io.Writer)
+	Stderr(err io.Writer)
+	RunExecutable(e string, p ...string) error
+}
+
+// GenerateOptions configure Generate.
+type GenerateOptions struct {
+	// Name identifies the build, e.g. the CI job name.
+	Name string
+	// Number identifies the build run, e.g. the CI build number.
+	Number string
+	// Started is the RFC3339 build start timestamp.
+	Started string
+	// PomPath is the module's pom.xml.
+	PomPath string
+}
+
+// Generate produces a build-info document for the module at options.PomPath: it parses
+// `dependency:list` and `help:evaluate` output to determine the module id and its resolved
+// dependencies, and inspects target/*.jar|war|pom for the artifacts the module produced.
+func Generate(options GenerateOptions, command mavenExecRunner) (*BuildInfo, error) {
+	moduleID, err := evaluateModuleID(options.PomPath, command)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate module id: %w", err)
+	}
+
+	dependencyListOutput, err := runMaven(command, options.PomPath, "dependency:list", "-DoutputAbsoluteArtifactFilename=true")
+	if err != nil {
+		return nil, fmt.Errorf("failed to run dependency:list: %w", err)
+	}
+	dependencies, err := parseDependencyList(dependencyListOutput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dependency:list output: %w", err)
+	}
+
+	artifacts, err := collectArtifacts(filepath.Dir(options.PomPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect module artifacts: %w", err)
+	}
+
+	return &BuildInfo{
+		Name:    options.Name,
+		Number:  options.Number,
+		Started: options.Started,
+		Modules: []Module{
+			{
+				ID:           moduleID,
+				Artifacts:    artifacts,
+				Dependencies: dependencies,
+			},
+		},
+	}, nil
+}
+
+func evaluateModuleID(pomPath string, command mavenExecRunner) (string, error) {
+	groupID, err := evaluate(pomPath, "project.groupId", command)
+	if err != nil {
+		return "", err
+	}
+	artifactID, err := evaluate(pomPath, "project.artifactId", command)
+	if err != nil {
+		return "", err
+	}
+	version, err := evaluate(pomPath, "project.version", command)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%s:%s", groupID, artifactID, version), nil
+}
+
+func evaluate(pomPath, expression string, command mavenExecRunner) (string, error) {
+	output, err := runMaven(command, pomPath, "org.apache.maven.plugins:maven-help-plugin:3.1.0:evaluate",
+		"-Dexpression="+expression, "-DforceStdout", "-q")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
+func runMaven(command mavenExecRunner, pomPath string, goal string, defines ...string) (string, error) {
+	var stdout bytes.Buffer
+	command.Stdout(&stdout)
+
+	args := []string{"--batch-mode", "--file", pomPath, goal}
+	args = append(args, defines...)
+	if err := command.RunExecutable("mvn", args...); err != nil {
+		return "", fmt.Errorf("failed to run 'mvn %s': %w", strings.Join(args, " "), err)
+	}
+	return stdout.String(), nil
+}
+
+// parseDependencyList parses the output of `dependency:list -DoutputAbsoluteArtifactFilename=true`,
+// whose relevant lines look like (Maven prefixes every line with "[INFO] " unless invoked
+// with -q):
+//
+//	[INFO]    com.google.guava:guava:jar:19.0:compile:/home/user/.m2/repository/.../guava-19.0.jar
+func parseDependencyList(output string) ([]Dependency, error) {
+	var dependencies []Dependency
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimPrefix(line, "[INFO] ")
+		if !strings.HasPrefix(line, "   ") && !strings.HasPrefix(line, "    ") {
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		parts := strings.Split(trimmed, ":")
+		if len(parts) < 5 {
+			continue
+		}
+
+		dep := Dependency{
+			ID:    strings.Join(parts[:len(parts)-2], ":"),
+			Scope: parts[len(parts)-2],
+		}
+
+		filePath := parts[len(parts)-1]
+		if checksums, err := checksumFile(filePath); err == nil {
+			dep.SHA1, dep.SHA256, dep.MD5 = checksums.sha1, checksums.sha256, checksums.md5
+		}
+		dependencies = append(dependencies, dep)
+	}
+	return dependencies, nil
+}
+
+// collectArtifacts globs dir/target/*.jar|war|pom and computes checksums for each.
+func collectArtifacts(dir string) ([]Artifact, error) {
+	var artifacts []Artifact
+	for _, pattern := range []string{"*.jar", "*.war", "*.pom"} {
+		matches, err := doublestar.Glob(filepath.Join(dir, "target", pattern))
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			checksums, err := checksumFile(match)
+			if err != nil {
+				return nil, err
+			}
+			artifacts = append(artifacts, Artifact{
+				Name:   filepath.Base(match),
+				SHA1:   checksums.sha1,
+				SHA256: checksums.sha256,
+				MD5:    checksums.md5,
+			})
+		}
+	}
+	return artifacts, nil
+}
+
+type fileChecksums struct {
+	sha1, sha256, md5 string
+}
+
+func checksumFile(path string) (fileChecksums, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return fileChecksums{}, err
+	}
+	defer file.Close()
+
+	sha1Hash, sha256Hash, md5Hash := sha1.New(), sha256.New(), md5.New()
+	writer := io.MultiWriter(sha1Hash, sha256Hash, md5Hash)
+	if _, err := io.Copy(writer, file); err != nil {
+		return fileChecksums{}, err
+	}
+
+	return fileChecksums{
+		sha1:   hex.EncodeToString(sha1Hash.Sum(nil)),
+		sha256: hex.EncodeToString(sha256Hash.Sum(nil)),
+		md5:    hex.EncodeToString(md5Hash.Sum(nil)),
+	}, nil
+}
@@ -0,0 +1,28 @@
+package buildinfo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDependencyListHandlesInfoPrefixedOutput(t *testing.T) {
+	// captured from `mvn dependency:list -DoutputAbsoluteArtifactFilename=true` without -q;
+	// Maven prefixes every line of plugin output with "[INFO] ".
+	output := `[INFO]
+[INFO] --- maven-dependency-plugin:3.1.2:list (default-cli) @ myapp ---
+[INFO] The following files have been resolved:
+[INFO]    com.google.guava:guava:jar:19.0:compile:/does/not/exist/guava-19.0.jar
+[INFO]    junit:junit:jar:4.12:test:/does/not/exist/junit-4.12.jar
+[INFO] ------------------------------------------------------------------------
+`
+
+	dependencies, err := parseDependencyList(output)
+
+	assert.NoError(t, err)
+	assert.Len(t, dependencies, 2)
+	assert.Equal(t, "com.google.guava:guava:jar:19.0", dependencies[0].ID)
+	assert.Equal(t, "compile", dependencies[0].Scope)
+	assert.Equal(t, "junit:junit:jar:4.12", dependencies[1].ID)
+	assert.Equal(t, "test", dependencies[1].Scope)
+}
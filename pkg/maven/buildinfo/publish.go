@@ -0,0 +1,50 @@
+package buildinfo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+)
+
+// PublishOptions configure Publish.
+type PublishOptions struct {
+	// URL is the repository manager's build-info upload endpoint, e.g.
+	// "http://artifactory.example.org/artifactory/api/build".
+	URL      string
+	Username string
+	Password string
+}
+
+// Publish uploads buildInfo as JSON to the repository manager's build-info endpoint, giving
+// downstream release/promotion steps a provenance record to query against.
+func Publish(buildInfo *BuildInfo, options PublishOptions) error {
+	payload, err := json.Marshal(buildInfo)
+	if err != nil {
+		return fmt.Errorf("failed to marshal build-info: %w", err)
+	}
+
+	httpClient := &piperhttp.Client{}
+	httpClient.SetOptions(piperhttp.ClientOptions{
+		Username: options.Username,
+		Password: options.Password,
+	})
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+
+	url := strings.TrimSuffix(options.URL, "/")
+	response, err := httpClient.SendRequest(http.MethodPut, url, bytes.NewReader(payload), header, nil)
+	if err != nil {
+		return fmt.Errorf("failed to publish build-info to '%s': %w", url, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("failed to publish build-info to '%s', server returned status %d", url, response.StatusCode)
+	}
+	return nil
+}
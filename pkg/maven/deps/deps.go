@@ -0,0 +1,89 @@
+// Package deps discovers a Maven project's dependency set without needing a container or
+// a downstream build, by driving the same maven-dependency-plugin goals a containerless
+// Java analyzer would use to assemble its working set.
+package deps
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/SAP/jenkins-library/pkg/maven"
+)
+
+// Dependency describes a single resolved Maven dependency, as reported by
+// dependency:tree, including the jar copied out by dependency:copy-dependencies.
+type Dependency struct {
+	GroupID      string
+	ArtifactID   string
+	Version      string
+	Scope        string
+	Classifier   string
+	FileLocation string
+	Children     []Dependency
+}
+
+type mavenExecRunner interface {
+	Stdout(out io.Writer)
+	Stderr(err io.Writer)
+	RunExecutable(e string, p ...string) error
+}
+
+// ResolveDependencies runs maven-dependency-plugin's tree and copy-dependencies goals
+// against pomPath and returns the resulting dependency tree, with FileLocation populated
+// from workDir once the jars referenced by the tree have been copied there.
+func ResolveDependencies(pomPath, workDir string, command mavenExecRunner) ([]Dependency, error) {
+	treeOutput, err := maven.Execute(&maven.ExecuteOptions{
+		PomPath:      pomPath,
+		Goals:        []string{"dependency:tree"},
+		Defines:      []string{"-DoutputType=text"},
+		ReturnStdout: true,
+	}, command)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run dependency:tree: %w", err)
+	}
+
+	tree, err := parseDependencyTree(treeOutput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dependency:tree output: %w", err)
+	}
+
+	_, err = maven.Execute(&maven.ExecuteOptions{
+		PomPath: pomPath,
+		Goals:   []string{"dependency:copy-dependencies"},
+		Defines: []string{"-DoutputDirectory=" + workDir},
+	}, command)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run dependency:copy-dependencies: %w", err)
+	}
+
+	populateFileLocations(tree, workDir)
+	return tree, nil
+}
+
+// ResolveSources runs maven-dependency-plugin's sources goal so that source jars for the
+// previously resolved dependencies become available alongside the binary jars.
+func ResolveSources(pomPath string, command mavenExecRunner) error {
+	_, err := maven.Execute(&maven.ExecuteOptions{
+		PomPath: pomPath,
+		Goals:   []string{"dependency:sources"},
+	}, command)
+	if err != nil {
+		return fmt.Errorf("failed to run dependency:sources: %w", err)
+	}
+	return nil
+}
+
+func populateFileLocations(deps []Dependency, workDir string) {
+	for i := range deps {
+		deps[i].FileLocation = jarFileName(workDir, deps[i])
+		populateFileLocations(deps[i].Children, workDir)
+	}
+}
+
+func jarFileName(workDir string, dep Dependency) string {
+	name := dep.ArtifactID + "-" + dep.Version
+	if dep.Classifier != "" {
+		name += "-" + dep.Classifier
+	}
+	return workDir + "/" + name + ".jar"
+}
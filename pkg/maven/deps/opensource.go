@@ -0,0 +1,63 @@
+package deps
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+)
+
+const mavenCentralSearchURL = "https://search.maven.org/solrsearch/select"
+
+// OpenSourceLabeler tags dependencies as open- or closed-source by checking whether their
+// exact coordinate is indexed on Maven Central.
+type OpenSourceLabeler struct {
+	httpClient *piperhttp.Client
+}
+
+// NewOpenSourceLabeler creates an OpenSourceLabeler using Maven Central's search API.
+func NewOpenSourceLabeler() *OpenSourceLabeler {
+	return &OpenSourceLabeler{httpClient: &piperhttp.Client{}}
+}
+
+type mavenCentralSearchResponse struct {
+	Response struct {
+		NumFound int `json:"numFound"`
+	} `json:"response"`
+}
+
+// IsOpenSource returns true if dep's exact groupId/artifactId/version is found on Maven
+// Central, which is used as a proxy for the artifact being open source.
+func (l *OpenSourceLabeler) IsOpenSource(dep Dependency) (bool, error) {
+	query := fmt.Sprintf("g:%s+AND+a:%s+AND+v:%s", dep.GroupID, dep.ArtifactID, dep.Version)
+	url := fmt.Sprintf("%s?q=%s&rows=1&wt=json", mavenCentralSearchURL, query)
+
+	response, err := l.httpClient.SendRequest(http.MethodGet, url, nil, nil, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to query maven central for '%s:%s:%s': %w", dep.GroupID, dep.ArtifactID, dep.Version, err)
+	}
+	defer response.Body.Close()
+
+	var result mavenCentralSearchResponse
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("failed to parse maven central response: %w", err)
+	}
+	return result.Response.NumFound > 0, nil
+}
+
+// Label queries Maven Central for each of deps and returns the subset found to be open
+// source. deps itself is not modified, and Children are not queried.
+func (l *OpenSourceLabeler) Label(deps []Dependency) ([]Dependency, error) {
+	var openSource []Dependency
+	for _, dep := range deps {
+		isOpenSource, err := l.IsOpenSource(dep)
+		if err != nil {
+			return nil, err
+		}
+		if isOpenSource {
+			openSource = append(openSource, dep)
+		}
+	}
+	return openSource, nil
+}
@@ -0,0 +1,117 @@
+package deps
+
+import (
+	"fmt"
+	"strings"
+)
+
+// node is a pointer-based intermediate tree form used while parsing. Appending directly to
+// a []Dependency held by a parent would invalidate sibling pointers already on the stack;
+// building the tree out of pointers first avoids that, and it is converted to the plain
+// value tree callers expect via toDependencies once parsing is complete.
+type node struct {
+	dep      Dependency
+	children []*node
+}
+
+// parseDependencyTree parses the output of `mvn dependency:tree -DoutputType=text`, e.g.:
+//
+//	[INFO] com.example:myapp:jar:1.0
+//	[INFO] +- com.google.guava:guava:jar:19.0:compile
+//	[INFO] |  \- com.google.code.findbugs:jsr305:jar:1.3.9:compile
+//	[INFO] \- junit:junit:jar:4.12:test
+//
+// Maven prefixes every line of plugin output with "[INFO] " unless invoked with -q; that
+// prefix is stripped before the indentation is inspected. The first line is the project's
+// own artifact and is not included in the result; its children become the top-level
+// Dependency slice.
+func parseDependencyTree(output string) ([]Dependency, error) {
+	var rootNodes []*node
+	var stack []*node // stack[i] is the parent node at depth i+1
+
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		line = strings.TrimPrefix(line, "[INFO] ")
+		depth, coordinate := splitTreeLine(line)
+		if depth == 0 {
+			// the project's own artifact; nothing to record, but reset the stack
+			stack = nil
+			continue
+		}
+
+		dep, err := parseCoordinate(coordinate)
+		if err != nil {
+			return nil, err
+		}
+
+		// depth 1 is a direct child of the project, i.e. a root of the returned tree
+		if depth > len(stack)+1 {
+			return nil, fmt.Errorf("malformed dependency tree, unexpected indentation in line '%s'", line)
+		}
+		stack = stack[:depth-1]
+		n := &node{dep: dep}
+
+		if depth == 1 {
+			rootNodes = append(rootNodes, n)
+		} else {
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, n)
+		}
+		stack = append(stack, n)
+	}
+
+	return toDependencies(rootNodes), nil
+}
+
+func toDependencies(nodes []*node) []Dependency {
+	deps := make([]Dependency, len(nodes))
+	for i, n := range nodes {
+		deps[i] = n.dep
+		deps[i].Children = toDependencies(n.children)
+	}
+	return deps
+}
+
+// splitTreeLine separates the indentation produced by maven-dependency-plugin ("|  ", "   ",
+// "+- ", "\- ") from the artifact coordinate, and returns the nesting depth (0 for the
+// project's own artifact on the first line).
+func splitTreeLine(line string) (depth int, coordinate string) {
+	for {
+		switch {
+		case strings.HasPrefix(line, "+- "), strings.HasPrefix(line, "\\- "):
+			return depth + 1, line[3:]
+		case strings.HasPrefix(line, "|  "), strings.HasPrefix(line, "   "):
+			line = line[3:]
+			depth++
+		default:
+			return depth, line
+		}
+	}
+}
+
+// parseCoordinate parses a single dependency:tree line's artifact coordinate, of the form
+// groupId:artifactId:packaging[:classifier]:version:scope.
+func parseCoordinate(coordinate string) (Dependency, error) {
+	parts := strings.Split(strings.TrimSpace(coordinate), ":")
+	switch len(parts) {
+	case 5:
+		return Dependency{
+			GroupID:    parts[0],
+			ArtifactID: parts[1],
+			Version:    parts[3],
+			Scope:      parts[4],
+		}, nil
+	case 6:
+		return Dependency{
+			GroupID:    parts[0],
+			ArtifactID: parts[1],
+			Classifier: parts[3],
+			Version:    parts[4],
+			Scope:      parts[5],
+		}, nil
+	default:
+		return Dependency{}, fmt.Errorf("unexpected coordinate format '%s'", coordinate)
+	}
+}
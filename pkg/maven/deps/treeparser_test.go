@@ -0,0 +1,48 @@
+package deps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDependencyTreeHandlesInfoPrefixedOutput(t *testing.T) {
+	// captured from `mvn dependency:tree -DoutputType=text` without -q; Maven prefixes every
+	// line of plugin output with "[INFO] ".
+	output := `[INFO]
+[INFO] --- maven-dependency-plugin:3.1.2:tree (default-cli) @ myapp ---
+[INFO] com.example:myapp:jar:1.0
+[INFO] +- com.google.guava:guava:jar:19.0:compile
+[INFO] |  \- com.google.code.findbugs:jsr305:jar:1.3.9:compile
+[INFO] \- junit:junit:jar:4.12:test
+[INFO] ------------------------------------------------------------------------
+`
+
+	deps, err := parseDependencyTree(output)
+
+	assert.NoError(t, err)
+	assert.Len(t, deps, 2)
+
+	guava := deps[0]
+	assert.Equal(t, "com.google.guava", guava.GroupID)
+	assert.Equal(t, "guava", guava.ArtifactID)
+	assert.Equal(t, "19.0", guava.Version)
+	assert.Equal(t, "compile", guava.Scope)
+	assert.Len(t, guava.Children, 1)
+	assert.Equal(t, "jsr305", guava.Children[0].ArtifactID)
+
+	junit := deps[1]
+	assert.Equal(t, "junit", junit.GroupID)
+	assert.Equal(t, "junit", junit.ArtifactID)
+	assert.Equal(t, "4.12", junit.Version)
+	assert.Equal(t, "test", junit.Scope)
+}
+
+func TestParseDependencyTreeEmptyProject(t *testing.T) {
+	output := "[INFO] com.example:myapp:jar:1.0\n"
+
+	deps, err := parseDependencyTree(output)
+
+	assert.NoError(t, err)
+	assert.Empty(t, deps)
+}
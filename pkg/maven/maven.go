@@ -2,6 +2,7 @@ package maven
 
 import (
 	"bytes"
+	"encoding/xml"
 	"fmt"
 	"github.com/bmatcuk/doublestar"
 	"io"
@@ -9,10 +10,14 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
 
 	piperhttp "github.com/SAP/jenkins-library/pkg/http"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/maven/buildinfo"
+	"github.com/SAP/jenkins-library/pkg/maven/settings"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 )
 
@@ -27,6 +32,23 @@ type ExecuteOptions struct {
 	Flags                       []string `json:"flags,omitempty"`
 	LogSuccessfulMavenTransfers bool     `json:"logSuccessfulMavenTransfers,omitempty"`
 	ReturnStdout                bool     `json:"returnStdout,omitempty"`
+	// ResolverConfig, if set, makes getParametersFromOptions synthesise a settings.xml
+	// pointing dependency and plugin resolution at the configured repository manager,
+	// merging it with ProjectSettingsFile if one was also provided.
+	ResolverConfig *ResolverConfig `json:"resolverConfig,omitempty"`
+	// BuildInfo, if set, makes Execute generate a build-info document for this invocation
+	// after a successful build and store it in BuildInfo.Result.
+	BuildInfo *BuildInfoOptions `json:"buildInfo,omitempty"`
+}
+
+// BuildInfoOptions configure the build-info document Execute generates after a successful
+// build, analogous to jfrog's build-info-maven-plugin bridge.
+type BuildInfoOptions struct {
+	Name    string `json:"name,omitempty"`
+	Number  string `json:"number,omitempty"`
+	Started string `json:"started,omitempty"`
+	// Result is populated by Execute once the build-info document has been generated.
+	Result *buildinfo.BuildInfo `json:"-"`
 }
 
 // EvaluateOptions are used by Evaluate() to construct the Maven command line.
@@ -36,6 +58,9 @@ type EvaluateOptions struct {
 	ProjectSettingsFile string `json:"projectSettingsFile,omitempty"`
 	GlobalSettingsFile  string `json:"globalSettingsFile,omitempty"`
 	M2Path              string `json:"m2Path,omitempty"`
+	// InstallParallelism bounds how many reactor modules InstallMavenArtifacts installs
+	// concurrently. Defaults to 1 (sequential) if zero or negative.
+	InstallParallelism int `json:"installParallelism,omitempty"`
 }
 
 type mavenExecRunner interface {
@@ -46,10 +71,10 @@ type mavenExecRunner interface {
 
 type mavenUtils interface {
 	FileExists(path string) (bool, error)
+	FileRead(path string) ([]byte, error)
+	FileWrite(path string, content []byte, perm os.FileMode) error
 	DownloadFile(url, filename string, header http.Header, cookies []*http.Cookie) error
 	glob(pattern string) (matches []string, err error)
-	getwd() (dir string, err error)
-	chdir(dir string) error
 }
 
 type utilsBundle struct {
@@ -68,14 +93,6 @@ func (u *utilsBundle) glob(pattern string) (matches []string, err error) {
 	return doublestar.Glob(pattern)
 }
 
-func (u *utilsBundle) getwd() (dir string, err error) {
-	return os.Getwd()
-}
-
-func (u *utilsBundle) chdir(dir string) error {
-	return os.Chdir(dir)
-}
-
 const mavenExecutable = "mvn"
 
 // Execute constructs a mvn command line from the given options, and uses the provided
@@ -96,6 +113,19 @@ func Execute(options *ExecuteOptions, command mavenExecRunner) (string, error) {
 		return "", fmt.Errorf("failed to run executable, command: '%s', error: %w", commandLine, err)
 	}
 
+	if options.BuildInfo != nil {
+		result, err := buildinfo.Generate(buildinfo.GenerateOptions{
+			Name:    options.BuildInfo.Name,
+			Number:  options.BuildInfo.Number,
+			Started: options.BuildInfo.Started,
+			PomPath: options.PomPath,
+		}, command)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate build-info: %w", err)
+		}
+		options.BuildInfo.Result = result
+	}
+
 	if stdOutBuf == nil {
 		return "", nil
 	}
@@ -160,12 +190,44 @@ func InstallFile(file, pomFile, m2Path string, command mavenExecRunner) error {
 	return nil
 }
 
-// InstallMavenArtifacts finds maven modules (identified by pom.xml files) and installs the artifacts into the local maven repository.
-func InstallMavenArtifacts(command mavenExecRunner, options EvaluateOptions) error {
-	return doInstallMavenArtifacts(command, options, newUtils())
+// InstallMavenArtifacts finds maven modules (identified by pom.xml files) and installs the
+// artifacts into the local maven repository. newCommand is called once for the flatten and
+// reactor-wide evaluation steps, which run one after another on that runner, and once per
+// installed module, so that - unlike a single shared mavenExecRunner - each install
+// genuinely runs in parallel without racing on another install's Stdout/Stderr/RunExecutable
+// state.
+func InstallMavenArtifacts(newCommand func() mavenExecRunner, options EvaluateOptions) error {
+	return doInstallMavenArtifacts(newCommand, options, newUtils())
 }
 
-func doInstallMavenArtifacts(command mavenExecRunner, options EvaluateOptions, utils mavenUtils) error {
+// packagingExtensions maps packaging and artifact type values to the file extension of the
+// artifact they produce, for types where that differs from the type name itself.
+var packagingExtensions = map[string]string{
+	"ejb":         "jar",
+	"bundle":      "jar",
+	"java-source": "jar",
+	"javadoc":     "jar",
+	"test-jar":    "jar",
+	"ejb-client":  "jar",
+}
+
+func artifactExtension(packaging string) string {
+	if extension, ok := packagingExtensions[packaging]; ok {
+		return extension
+	}
+	return packaging
+}
+
+// attachedArtifact is a secondary artifact reported by project.attachedArtifacts, such as a
+// sources or javadoc jar, or an additional classifier produced by an assembly/shade build.
+type attachedArtifact struct {
+	Type       string
+	Classifier string
+}
+
+func doInstallMavenArtifacts(newCommand func() mavenExecRunner, options EvaluateOptions, utils mavenUtils) error {
+	command := newCommand()
+
 	err := flattenPom(command)
 	if err != nil {
 		return err
@@ -176,96 +238,211 @@ func doInstallMavenArtifacts(command mavenExecRunner, options EvaluateOptions, u
 		return err
 	}
 
-	oldWorkingDirectory, err := utils.getwd()
+	var modules []string
+	for _, pomFile := range pomFiles {
+		modules = append(modules, path.Dir(pomFile))
+	}
+
+	moduleByArtifactID, err := mapModulesByArtifactID(modules, utils)
 	if err != nil {
 		return err
 	}
 
-	// Set pom path fix here because we will change into the respective pom's directory
-	options.PomPath = "pom.xml"
-	for _, pomFile := range pomFiles {
-		log.Entry().Info("Installing maven artifacts from module: " + pomFile)
-		dir := path.Dir(pomFile)
-		err = utils.chdir(dir)
-		if err != nil {
-			return err
+	packagings, err := evaluateReactorWide("project.packaging", modules, moduleByArtifactID, command)
+	if err != nil {
+		return err
+	}
+	finalNames, err := evaluateReactorWide("project.build.finalName", modules, moduleByArtifactID, command)
+	if err != nil {
+		return err
+	}
+	attachedArtifactsByModule, err := evaluateReactorWide("project.attachedArtifacts", modules, moduleByArtifactID, command)
+	if err != nil {
+		return err
+	}
+
+	return installModulesConcurrently(modules, options.InstallParallelism, func(module string) error {
+		log.Entry().Info("Installing maven artifacts from module: " + module)
+		pomFile := path.Join(module, "pom.xml")
+		m2Path := options.M2Path
+
+		// each worker gets its own mavenExecRunner, so installs genuinely run in
+		// parallel instead of racing on a single runner's Stdout/Stderr/RunExecutable.
+		moduleCommand := newCommand()
+
+		if packagings[module] == "pom" {
+			return InstallFile("", pomFile, m2Path, moduleCommand)
 		}
+		return installModuleArtifacts(moduleCommand, utils, module, pomFile, m2Path, packagings[module], finalNames[module], attachedArtifactsByModule[module])
+	})
+}
 
-		packaging, err := Evaluate(&options, "project.packaging", command)
-		if err != nil {
+// installModuleArtifacts installs a module's main artifact (honoring its packaging's file
+// extension, beyond the common jar/war/pom, e.g. ear/ejb/rar/hpi/nbm) along with every
+// attached artifact/classifier Maven reports for it, rather than pattern-matching for a
+// hardcoded "-classes.jar" classifier.
+func installModuleArtifacts(command mavenExecRunner, utils mavenUtils, module, pomFile, m2Path, packaging, finalName, attachedArtifactsValue string) error {
+	if finalName == "" {
+		log.Entry().Warn("project.build.finalName is empty for module '" + module + "', skipping install of artifact. Installing only the pom file.")
+		return InstallFile("", pomFile, m2Path, command)
+	}
+
+	mainArtifact := path.Join(module, "target", finalName+"."+artifactExtension(packaging))
+	if exists, _ := utils.FileExists(mainArtifact); exists {
+		if err := InstallFile(mainArtifact, pomFile, m2Path, command); err != nil {
 			return err
 		}
+	}
 
-		if packaging == "pom" {
-			err = InstallFile("", "pom.xml", options.M2Path, command)
-			if err != nil {
-				return err
+	for _, attached := range parseAttachedArtifacts(attachedArtifactsValue) {
+		name := finalName
+		if attached.Classifier != "" {
+			name += "-" + attached.Classifier
+		}
+		file := path.Join(module, "target", name+"."+artifactExtension(attached.Type))
+		if exists, _ := utils.FileExists(file); exists {
+			// the attached artifact's type and classifier are passed explicitly, so it is not
+			// installed under the main artifact's coordinates.
+			defines := []string{"-Dfile=" + file, "-DpomFile=" + pomFile, "-Dpackaging=" + attached.Type}
+			if attached.Classifier != "" {
+				defines = append(defines, "-Dclassifier="+attached.Classifier)
 			}
-		} else {
-			err = installJarWarArtifacts(command, utils, options)
-			if err != nil {
-				return err
+			installOptions := ExecuteOptions{Goals: []string{"install:install-file"}, Defines: defines, PomPath: pomFile, M2Path: m2Path}
+			if _, err := Execute(&installOptions, command); err != nil {
+				return fmt.Errorf("failed to install attached artifact '%s': %w", file, err)
 			}
 		}
+	}
+	return nil
+}
 
-		err = utils.chdir(oldWorkingDirectory)
-		if err != nil {
-			return err
+// parseAttachedArtifacts parses the toString representation maven-help-plugin prints for
+// project.attachedArtifacts, a list of entries of the form "groupId:artifactId:type:version"
+// or, when classified, "groupId:artifactId:type:classifier:version".
+func parseAttachedArtifacts(value string) []attachedArtifact {
+	var attached []attachedArtifact
+	value = strings.Trim(strings.TrimSpace(value), "[]")
+	if value == "" {
+		return attached
+	}
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		switch len(parts) {
+		case 4:
+			attached = append(attached, attachedArtifact{Type: parts[2]})
+		case 5:
+			attached = append(attached, attachedArtifact{Type: parts[2], Classifier: parts[3]})
 		}
 	}
-	return err
+	return attached
 }
 
-func installJarWarArtifacts(command mavenExecRunner, utils mavenUtils, options EvaluateOptions) error {
-	finalName, err := Evaluate(&options, "project.build.finalName", command)
-	if err != nil {
-		return err
+// installModulesConcurrently installs every module using a bounded worker pool sized by
+// parallelism (treated as 1, i.e. sequential, when zero or negative), and returns the first
+// error encountered.
+func installModulesConcurrently(modules []string, parallelism int, install func(module string) error) error {
+	if parallelism <= 0 {
+		parallelism = 1
 	}
-	if finalName == "" {
-		log.Entry().Warn("project.build.finalName is empty, skipping install of artifact. Installing only the pom file.")
-		err = InstallFile("", "pom.xml", options.M2Path, command)
-		if err != nil {
-			return err
-		}
-		return nil
+
+	moduleChan := make(chan string)
+	errChan := make(chan error, len(modules))
+
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for module := range moduleChan {
+				errChan <- install(module)
+			}
+		}()
 	}
-	jarExists, _ := utils.FileExists(jarFile(finalName))
-	warExists, _ := utils.FileExists(warFile(finalName))
-	classesJarExists, _ := utils.FileExists(classesJarFile(finalName))
 
-	if jarExists {
-		err = InstallFile(jarFile(finalName), "pom.xml", options.M2Path, command)
-		if err != nil {
-			return err
-		}
+	for _, module := range modules {
+		moduleChan <- module
 	}
+	close(moduleChan)
+	wg.Wait()
+	close(errChan)
 
-	if warExists {
-		err = InstallFile(warFile(finalName), "pom.xml", options.M2Path, command)
+	for err := range errChan {
 		if err != nil {
 			return err
 		}
 	}
+	return nil
+}
+
+// mapModulesByArtifactID reads each module's own pom.xml to determine its artifactId,
+// without invoking Maven, so reactor-wide evaluation output (which Maven logs per-module
+// under its artifactId) can be attributed back to the right module directory.
+func mapModulesByArtifactID(modules []string, utils mavenUtils) (map[string]string, error) {
+	type minimalPom struct {
+		ArtifactID string `xml:"artifactId"`
+	}
 
-	if classesJarExists {
-		err = InstallFile(classesJarFile(finalName), "pom.xml", options.M2Path, command)
+	byArtifactID := make(map[string]string, len(modules))
+	for _, module := range modules {
+		content, err := utils.FileRead(path.Join(module, "pom.xml"))
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("failed to read '%s': %w", path.Join(module, "pom.xml"), err)
 		}
+		var pom minimalPom
+		if err := xml.Unmarshal(content, &pom); err != nil {
+			return nil, fmt.Errorf("failed to parse '%s': %w", path.Join(module, "pom.xml"), err)
+		}
+		byArtifactID[pom.ArtifactID] = module
 	}
-	return nil
+	return byArtifactID, nil
 }
 
-func jarFile(finalName string) string {
-	return "target/" + finalName + ".jar"
-}
+// reactorExecutionBanner matches the plugin execution banner Maven logs for each reactor
+// module it runs a goal against, e.g.:
+//
+//	[INFO] --- maven-help-plugin:3.1.0:evaluate (default-cli) @ my-artifact-id ---
+var reactorExecutionBanner = regexp.MustCompile(`--- .+ @ (\S+) ---`)
+
+// evaluateReactorWide evaluates expression once for the whole reactor (a single mvn
+// invocation covering every module in modules, instead of one invocation per module) and
+// attributes each module's value back to its directory using moduleByArtifactID.
+func evaluateReactorWide(expression string, modules []string, moduleByArtifactID map[string]string, command mavenExecRunner) (map[string]string, error) {
+	output, err := Execute(&ExecuteOptions{
+		PomPath:      "pom.xml",
+		Flags:        []string{"-pl", strings.Join(modules, ",")},
+		Goals:        []string{"org.apache.maven.plugins:maven-help-plugin:3.1.0:evaluate"},
+		Defines:      []string{"-Dexpression=" + expression, "-DforceStdout"},
+		ReturnStdout: true,
+	}, command)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate '%s' across the reactor: %w", expression, err)
+	}
 
-func classesJarFile(finalName string) string {
-	return "target/" + finalName + "-classes.jar"
+	result := make(map[string]string, len(modules))
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		match := reactorExecutionBanner.FindStringSubmatch(line)
+		if match == nil || i+1 >= len(lines) {
+			continue
+		}
+		module, ok := moduleByArtifactID[match[1]]
+		if !ok {
+			continue
+		}
+		result[module] = strings.TrimSpace(valueBeforeNextLogLine(lines[i+1]))
+	}
+	return result, nil
 }
 
-func warFile(finalName string) string {
-	return "target/" + finalName + ".war"
+// valueBeforeNextLogLine returns the part of line before Maven's next "[INFO]"-prefixed log
+// line. -DforceStdout prints the evaluated expression with no trailing newline, so it ends up
+// concatenated onto the front of whatever Maven itself logs next (typically a blank "[INFO] "
+// line); this strips that off instead of treating the whole line as the value.
+func valueBeforeNextLogLine(line string) string {
+	if idx := strings.Index(line, "[INFO]"); idx != -1 {
+		return line[:idx]
+	}
+	return line
 }
 
 func flattenPom(command mavenExecRunner) error {
@@ -299,11 +476,24 @@ func getParametersFromOptions(options *ExecuteOptions, utils mavenUtils) ([]stri
 		parameters = append(parameters, "--global-settings", globalSettingsFileName)
 	}
 
+	projectSettingsFileName := ""
 	if options.ProjectSettingsFile != "" {
-		projectSettingsFileName, err := downloadSettingsIfURL(options.ProjectSettingsFile, ".pipeline/mavenProjectSettings.xml", utils)
+		var err error
+		projectSettingsFileName, err = downloadSettingsIfURL(options.ProjectSettingsFile, ".pipeline/mavenProjectSettings.xml", utils)
 		if err != nil {
 			return nil, err
 		}
+	}
+
+	if options.ResolverConfig != nil {
+		resolverSettingsFileName, err := synthesizeResolverSettings(options.ResolverConfig, projectSettingsFileName, utils)
+		if err != nil {
+			return nil, fmt.Errorf("failed to synthesize resolver settings: %w", err)
+		}
+		projectSettingsFileName = resolverSettingsFileName
+	}
+
+	if projectSettingsFileName != "" {
 		parameters = append(parameters, "--settings", projectSettingsFileName)
 	}
 
@@ -346,19 +536,86 @@ func downloadSettingsIfURL(settingsFileOption, settingsFile string, utils mavenU
 	return result, nil
 }
 
-// ToDo replace with pkg/maven/settings GetSettingsFile
-func downloadSettingsFromURL(url, filename string, utils mavenUtils) error {
-	exists, _ := utils.FileExists(filename)
-	if exists {
-		log.Entry().Infof("Not downloading maven settings file, because it already exists at '%s'", filename)
-		return nil
-	}
-	err := utils.DownloadFile(url, filename, nil, nil)
+// synthesizeResolverSettings renders a settings.xml for resolverConfig into .pipeline/
+// and, if userSettingsFile is non-empty, merges the user-provided settings into it via
+// mergeSettings, which inlines the children of the user's <servers>, <mirrors>, <profiles>
+// and <activeProfiles> sections into the matching generated sections.
+func synthesizeResolverSettings(resolverConfig *ResolverConfig, userSettingsFile string, utils mavenUtils) (string, error) {
+	rendered, err := NewResolver(*resolverConfig).RenderSettings()
 	if err != nil {
-		return fmt.Errorf("failed to download maven settings from URL '%s' to file '%s': %w",
-			url, filename, err)
+		return "", err
 	}
-	return nil
+
+	if userSettingsFile != "" {
+		userSettings, err := utils.FileRead(userSettingsFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read project settings file '%s': %w", userSettingsFile, err)
+		}
+		rendered = mergeSettings(rendered, string(userSettings))
+	}
+
+	const resolverSettingsFile = ".pipeline/mavenResolverSettings.xml"
+	if err := utils.FileWrite(resolverSettingsFile, []byte(rendered), 0666); err != nil {
+		return "", fmt.Errorf("failed to write '%s': %w", resolverSettingsFile, err)
+	}
+	return resolverSettingsFile, nil
+}
+
+// settingsSection captures a settings.xml element's raw child content, so it can be
+// re-spliced into another document without being re-serialized (and without the formatting
+// and whitespace of the original being disturbed).
+type settingsSection struct {
+	InnerXML string `xml:",innerxml"`
+}
+
+// userSettingsSections is the subset of settings.xml that mergeSettings cares about.
+// Unmarshaling (rather than locating tags with strings.Index) correctly ignores
+// commented-out sections and handles self-closing elements.
+type userSettingsSections struct {
+	XMLName        xml.Name         `xml:"settings"`
+	Servers        *settingsSection `xml:"servers"`
+	Mirrors        *settingsSection `xml:"mirrors"`
+	Profiles       *settingsSection `xml:"profiles"`
+	ActiveProfiles *settingsSection `xml:"activeProfiles"`
+}
+
+// mergeSettings inlines the children of userSettings' <servers>, <mirrors>, <profiles> and
+// <activeProfiles> sections into the matching sections of generatedSettings, right before
+// each one's closing tag. generatedSettings always declares all four sections (see
+// resolverSettingsTemplate), so this avoids producing a settings.xml with two <servers>
+// blocks, which Maven rejects as schema-invalid.
+func mergeSettings(generatedSettings, userSettings string) string {
+	var doc userSettingsSections
+	if err := xml.Unmarshal([]byte(userSettings), &doc); err != nil {
+		log.Entry().WithError(err).Warn("failed to parse project settings file as XML, skipping merge")
+		return generatedSettings
+	}
+
+	merged := generatedSettings
+	for _, section := range []struct {
+		tag     string
+		content *settingsSection
+	}{
+		{"servers", doc.Servers},
+		{"mirrors", doc.Mirrors},
+		{"profiles", doc.Profiles},
+		{"activeProfiles", doc.ActiveProfiles},
+	} {
+		if section.content == nil || strings.TrimSpace(section.content.InnerXML) == "" {
+			continue
+		}
+		closeTag := "</" + section.tag + ">"
+		idx := strings.Index(merged, closeTag)
+		if idx == -1 {
+			continue
+		}
+		merged = merged[:idx] + section.content.InnerXML + merged[idx:]
+	}
+	return merged
+}
+
+func downloadSettingsFromURL(url, filename string, utils mavenUtils) error {
+	return settings.DownloadSettingsFromURL(url, filename, utils)
 }
 
 func GetTestModulesExcludes() []string {
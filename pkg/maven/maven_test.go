@@ -0,0 +1,132 @@
+package maven
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeExecRunner is a minimal mavenExecRunner that writes a fixed stdout payload, emulating
+// what command.Command would capture from a real mvn invocation.
+type fakeExecRunner struct {
+	stdout io.Writer
+	output string
+}
+
+func (f *fakeExecRunner) Stdout(out io.Writer) { f.stdout = out }
+func (f *fakeExecRunner) Stderr(io.Writer)     {}
+func (f *fakeExecRunner) RunExecutable(e string, p ...string) error {
+	if f.stdout != nil {
+		_, _ = io.WriteString(f.stdout, f.output)
+	}
+	return nil
+}
+
+const generatedSettings = `<settings>
+  <servers>
+    <server>
+      <id>mavenResolver</id>
+      <username>resolver</username>
+      <password>secret</password>
+    </server>
+  </servers>
+  <mirrors>
+    <mirror>
+      <id>mavenResolver</id>
+      <mirrorOf>*</mirrorOf>
+      <url>https://resolver.example.org</url>
+    </mirror>
+  </mirrors>
+  <profiles>
+    <profile>
+      <id>mavenResolver</id>
+    </profile>
+  </profiles>
+  <activeProfiles>
+    <activeProfile>mavenResolver</activeProfile>
+  </activeProfiles>
+</settings>
+`
+
+func TestMergeSettingsInlinesUserServers(t *testing.T) {
+	userSettings := `<settings>
+  <servers>
+    <server>
+      <id>internal-repo</id>
+      <username>user</username>
+      <password>pass</password>
+    </server>
+  </servers>
+</settings>`
+
+	merged := mergeSettings(generatedSettings, userSettings)
+
+	assert.Contains(t, merged, "<id>mavenResolver</id>")
+	assert.Contains(t, merged, "<id>internal-repo</id>")
+	// only one <servers> container, not two
+	assert.Equal(t, 1, strings.Count(merged, "<servers>"))
+}
+
+func TestMergeSettingsIgnoresSelfClosingSections(t *testing.T) {
+	userSettings := `<settings>
+  <servers/>
+  <mirrors></mirrors>
+</settings>`
+
+	merged := mergeSettings(generatedSettings, userSettings)
+
+	assert.Equal(t, generatedSettings, merged)
+}
+
+func TestMergeSettingsIgnoresCommentedOutSections(t *testing.T) {
+	userSettings := `<settings>
+  <!--
+  <servers>
+    <server>
+      <id>should-not-appear</id>
+    </server>
+  </servers>
+  -->
+</settings>`
+
+	merged := mergeSettings(generatedSettings, userSettings)
+
+	assert.Equal(t, generatedSettings, merged)
+	assert.NotContains(t, merged, "should-not-appear")
+}
+
+func TestMergeSettingsReturnsGeneratedOnInvalidXML(t *testing.T) {
+	merged := mergeSettings(generatedSettings, "<settings><servers>")
+
+	assert.Equal(t, generatedSettings, merged)
+}
+
+func TestEvaluateReactorWideHandlesConcatenatedForceStdoutValue(t *testing.T) {
+	// captured from `mvn help:evaluate -DforceStdout` without -q: forceStdout prints the
+	// value with no trailing newline, so it runs straight into the next "[INFO] " log line.
+	output := `[INFO] Scanning for projects...
+[INFO] ------------------------------------------------------------------------
+[INFO] --- maven-help-plugin:3.1.0:evaluate (default-cli) @ module-a ---
+1.0.0-SNAPSHOT[INFO]
+[INFO] --- maven-help-plugin:3.1.0:evaluate (default-cli) @ module-b ---
+1.0.0-SNAPSHOT[INFO]
+[INFO] ------------------------------------------------------------------------
+[INFO] BUILD SUCCESS
+[INFO] ------------------------------------------------------------------------
+`
+	runner := &fakeExecRunner{output: output}
+	moduleByArtifactID := map[string]string{
+		"module-a": "dir-a",
+		"module-b": "dir-b",
+	}
+
+	result, err := evaluateReactorWide("project.version", []string{"dir-a", "dir-b"}, moduleByArtifactID, runner)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"dir-a": "1.0.0-SNAPSHOT",
+		"dir-b": "1.0.0-SNAPSHOT",
+	}, result)
+}
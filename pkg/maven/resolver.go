@@ -0,0 +1,119 @@
+package maven
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// ResolverConfig points Maven's dependency and plugin resolution at a repository manager
+// instead of Maven Central, so builds can run against a private mirror in a hermetic way.
+type ResolverConfig struct {
+	// Type selects the repository manager flavour, "artifactory" or "nexus".
+	Type string `json:"type,omitempty"`
+	// URL is the base URL of the repository manager.
+	URL string `json:"url,omitempty"`
+	// Repository is the name/key of the repository releases and snapshots are resolved from.
+	Repository string `json:"repository,omitempty"`
+	Username   string `json:"username,omitempty"`
+	Password   string `json:"password,omitempty"`
+}
+
+// Resolver renders a settings.xml that makes Maven resolve dependencies and plugins from
+// the repository manager described by a ResolverConfig.
+type Resolver interface {
+	// RenderSettings returns the settings.xml content for the configured repository.
+	RenderSettings() (string, error)
+}
+
+// NewResolver creates a Resolver for the given configuration.
+func NewResolver(config ResolverConfig) Resolver {
+	return &resolver{config: config}
+}
+
+type resolver struct {
+	config ResolverConfig
+}
+
+const resolverServerID = "mavenResolver"
+
+const resolverSettingsTemplate = `<settings>
+  <servers>
+    <server>
+      <id>{{.ServerID}}</id>
+      <username>{{.Username}}</username>
+      <password>{{.Password}}</password>
+    </server>
+  </servers>
+  <mirrors>
+    <mirror>
+      <id>{{.ServerID}}</id>
+      <mirrorOf>*</mirrorOf>
+      <url>{{.URL}}</url>
+    </mirror>
+  </mirrors>
+  <profiles>
+    <profile>
+      <id>{{.ServerID}}</id>
+      <repositories>
+        <repository>
+          <id>{{.ServerID}}</id>
+          <url>{{.URL}}</url>
+          <releases><enabled>true</enabled></releases>
+          <snapshots><enabled>true</enabled></snapshots>
+        </repository>
+      </repositories>
+      <pluginRepositories>
+        <pluginRepository>
+          <id>{{.ServerID}}</id>
+          <url>{{.URL}}</url>
+        </pluginRepository>
+      </pluginRepositories>
+    </profile>
+  </profiles>
+  <activeProfiles>
+    <activeProfile>{{.ServerID}}</activeProfile>
+  </activeProfiles>
+</settings>
+`
+
+func (r *resolver) RenderSettings() (string, error) {
+	resolutionURL, err := r.resolutionURL()
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New("settings").Parse(resolverSettingsTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var rendered bytes.Buffer
+	err = tmpl.Execute(&rendered, struct {
+		ServerID           string
+		Username, Password string
+		URL                string
+	}{
+		ServerID: resolverServerID,
+		Username: r.config.Username,
+		Password: r.config.Password,
+		URL:      resolutionURL,
+	})
+	if err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
+
+func (r *resolver) resolutionURL() (string, error) {
+	baseURL := strings.TrimSuffix(r.config.URL, "/")
+	switch r.config.Type {
+	case "artifactory":
+		return baseURL + "/" + r.config.Repository, nil
+	case "nexus":
+		return baseURL + "/repository/" + r.config.Repository, nil
+	default:
+		return "", fmt.Errorf("unsupported resolver type '%s', must be 'artifactory' or 'nexus'", r.config.Type)
+	}
+}
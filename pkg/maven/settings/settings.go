@@ -0,0 +1,191 @@
+// Package settings manipulates Maven settings.xml files: downloading a base file and
+// injecting <server>, <mirror> and <profile> entries into it, so that steps needing
+// credentials for a repository manager no longer have to embed them on the Maven command
+// line (e.g. via -Dnexus.user=...).
+package settings
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+)
+
+// DownloadUtils abstracts the file and network operations needed by this package.
+type DownloadUtils interface {
+	FileExists(filename string) (bool, error)
+	FileRead(filename string) ([]byte, error)
+	FileWrite(filename string, content []byte, perm os.FileMode) error
+	DownloadFile(url, filename string, header http.Header, cookies []*http.Cookie) error
+}
+
+// Server describes a <server> entry to inject into settings.xml, identifying credentials
+// for a repository, distribution management target, or similar server id. If HTTPHeaderName
+// is set, a <configuration><httpHeaders> block is emitted for token-based auth instead of
+// plain username/password.
+type Server struct {
+	ID              string
+	Username        string
+	Password        string
+	HTTPHeaderName  string
+	HTTPHeaderValue string
+}
+
+// Mirror describes a <mirror> entry to inject into settings.xml.
+type Mirror struct {
+	ID       string
+	MirrorOf string
+	URL      string
+}
+
+// Repository describes a <repository> or <pluginRepository> entry of a Profile.
+type Repository struct {
+	ID  string
+	URL string
+}
+
+// Profile describes a <profile> entry to inject into settings.xml, along with activating it.
+type Profile struct {
+	ID                 string
+	Repositories       []Repository
+	PluginRepositories []Repository
+}
+
+// DownloadSettingsFromURL downloads a base settings.xml from url to filename, unless a file
+// already exists there. pkg/maven's downloadSettingsFromURL delegates to it.
+func DownloadSettingsFromURL(url, filename string, utils DownloadUtils) error {
+	exists, _ := utils.FileExists(filename)
+	if exists {
+		log.Entry().Infof("Not downloading maven settings file, because it already exists at '%s'", filename)
+		return nil
+	}
+	err := utils.DownloadFile(url, filename, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to download maven settings from URL '%s' to file '%s': %w", url, filename, err)
+	}
+	return nil
+}
+
+// serversFallbackBefore lists the tags, in priority order, that a newly created <servers>
+// block is inserted before when settings.xml has no <servers> element at all. This mirrors
+// the order in which Maven's settings.xml schema declares the sibling elements of <servers>.
+// Sibling elements are anchored at their opening tag, so the new block lands before the
+// sibling rather than nested inside it.
+var serversFallbackBefore = []string{
+	"<proxies>", "<proxies/>",
+	"<offline>",
+	"<usePluginRegistry>",
+	"<interactiveMode>",
+	"<localRepository>",
+	"</settings>",
+}
+
+// AddServerEntry injects a <server> entry for server into the <servers> block of settingsXML,
+// creating the block if it is missing or self-closing, and returns the updated document.
+func AddServerEntry(settingsXML []byte, server Server) ([]byte, error) {
+	return injectBlock(settingsXML, "servers", renderServer(server), serversFallbackBefore)
+}
+
+// AddMirrorEntry injects a <mirror> entry for mirror into the <mirrors> block of settingsXML,
+// creating the block if it is missing or self-closing, and returns the updated document.
+func AddMirrorEntry(settingsXML []byte, mirror Mirror) ([]byte, error) {
+	return injectBlock(settingsXML, "mirrors", renderMirror(mirror), []string{"<profiles/>", "<profiles>", "</settings>"})
+}
+
+// AddProfileEntry injects a <profile> entry for profile into the <profiles> block of
+// settingsXML, activates it via <activeProfiles>, and returns the updated document.
+func AddProfileEntry(settingsXML []byte, profile Profile) ([]byte, error) {
+	updated, err := injectBlock(settingsXML, "profiles", renderProfile(profile), []string{"</settings>"})
+	if err != nil {
+		return nil, err
+	}
+	return injectBlock(updated, "activeProfiles", fmt.Sprintf("<activeProfile>%s</activeProfile>", escape(profile.ID)), []string{"</settings>"})
+}
+
+func renderServer(server Server) string {
+	if server.HTTPHeaderName != "" {
+		return fmt.Sprintf(`<server>
+  <id>%s</id>
+  <configuration>
+    <httpHeaders>
+      <property>
+        <name>%s</name>
+        <value>%s</value>
+      </property>
+    </httpHeaders>
+  </configuration>
+</server>`, escape(server.ID), escape(server.HTTPHeaderName), escape(server.HTTPHeaderValue))
+	}
+	return fmt.Sprintf(`<server>
+  <id>%s</id>
+  <username>%s</username>
+  <password>%s</password>
+</server>`, escape(server.ID), escape(server.Username), escape(server.Password))
+}
+
+func renderMirror(mirror Mirror) string {
+	return fmt.Sprintf(`<mirror>
+  <id>%s</id>
+  <mirrorOf>%s</mirrorOf>
+  <url>%s</url>
+</mirror>`, escape(mirror.ID), escape(mirror.MirrorOf), escape(mirror.URL))
+}
+
+func renderProfile(profile Profile) string {
+	var repositories, pluginRepositories strings.Builder
+	for _, repo := range profile.Repositories {
+		repositories.WriteString(renderRepository("repository", repo))
+	}
+	for _, repo := range profile.PluginRepositories {
+		pluginRepositories.WriteString(renderRepository("pluginRepository", repo))
+	}
+	return fmt.Sprintf(`<profile>
+  <id>%s</id>
+  <repositories>
+%s  </repositories>
+  <pluginRepositories>
+%s  </pluginRepositories>
+</profile>`, escape(profile.ID), repositories.String(), pluginRepositories.String())
+}
+
+func renderRepository(tag string, repo Repository) string {
+	return fmt.Sprintf("    <%s>\n      <id>%s</id>\n      <url>%s</url>\n    </%s>\n", tag, escape(repo.ID), escape(repo.URL), tag)
+}
+
+func escape(value string) string {
+	replacer := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	return replacer.Replace(value)
+}
+
+// injectBlock inserts entryXML into the named tag's block within xml. If the tag already
+// has an open/close pair, entryXML is inserted right before the closing tag. If the tag is
+// present but self-closing (<tag/> or <tag />), it is expanded into an open/close pair
+// wrapping entryXML. If the tag is missing altogether, a new block is inserted before the
+// first of fallbackBefore found in xml.
+func injectBlock(xml []byte, tag, entryXML string, fallbackBefore []string) ([]byte, error) {
+	doc := string(xml)
+	openTag := "<" + tag + ">"
+	closeTag := "</" + tag + ">"
+
+	if idx := strings.Index(doc, closeTag); idx != -1 {
+		return []byte(doc[:idx] + entryXML + "\n" + doc[idx:]), nil
+	}
+
+	selfClosing := regexp.MustCompile(`<` + tag + `\s*/>`)
+	if loc := selfClosing.FindStringIndex(doc); loc != nil {
+		block := openTag + "\n" + entryXML + "\n" + closeTag
+		return []byte(doc[:loc[0]] + block + doc[loc[1]:]), nil
+	}
+
+	block := openTag + "\n" + entryXML + "\n" + closeTag + "\n"
+	for _, marker := range fallbackBefore {
+		if idx := strings.Index(doc, marker); idx != -1 {
+			return []byte(doc[:idx] + block + doc[idx:]), nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not find a place to insert <%s> into settings.xml", tag)
+}
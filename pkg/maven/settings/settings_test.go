@@ -0,0 +1,142 @@
+package settings
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddServerEntryToExistingServersBlock(t *testing.T) {
+	doc := []byte(`<settings>
+  <servers>
+    <server>
+      <id>existing</id>
+    </server>
+  </servers>
+</settings>`)
+
+	result, err := AddServerEntry(doc, Server{ID: "nexus", Username: "admin", Password: "secret"})
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(result), "<id>existing</id>")
+	assert.Contains(t, string(result), "<id>nexus</id>")
+	assert.Contains(t, string(result), "<username>admin</username>")
+	assert.True(t, strings.Index(string(result), "<id>nexus</id>") < strings.Index(string(result), "</servers>"))
+}
+
+func TestAddServerEntryExpandsSelfClosingServersTag(t *testing.T) {
+	doc := []byte(`<settings>
+  <servers/>
+</settings>`)
+
+	result, err := AddServerEntry(doc, Server{ID: "nexus", Username: "admin", Password: "secret"})
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(result), "<servers>")
+	assert.Contains(t, string(result), "</servers>")
+	assert.Contains(t, string(result), "<id>nexus</id>")
+	assert.NotContains(t, string(result), "<servers/>")
+}
+
+func TestAddServerEntryExpandsSelfClosingServersTagWithSpace(t *testing.T) {
+	doc := []byte(`<settings>
+  <servers />
+</settings>`)
+
+	result, err := AddServerEntry(doc, Server{ID: "nexus", Username: "admin", Password: "secret"})
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(result), "<id>nexus</id>")
+}
+
+func TestAddServerEntryUsesHTTPHeaderForTokenAuth(t *testing.T) {
+	doc := []byte(`<settings><servers></servers></settings>`)
+
+	result, err := AddServerEntry(doc, Server{ID: "nexus", HTTPHeaderName: "Authorization", HTTPHeaderValue: "Bearer xyz"})
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(result), "<httpHeaders>")
+	assert.Contains(t, string(result), "<name>Authorization</name>")
+	assert.Contains(t, string(result), "<value>Bearer xyz</value>")
+}
+
+func TestAddServerEntryCreatesMissingServersBlockBeforeProxies(t *testing.T) {
+	doc := []byte(`<settings>
+  <proxies>
+    <proxy><id>proxy1</id></proxy>
+  </proxies>
+</settings>`)
+
+	result, err := AddServerEntry(doc, Server{ID: "nexus", Username: "admin", Password: "secret"})
+
+	assert.NoError(t, err)
+	assert.True(t, strings.Index(string(result), "<servers>") < strings.Index(string(result), "<proxies>"))
+}
+
+func TestAddServerEntryCreatesMissingServersBlockBeforeSelfClosingProxies(t *testing.T) {
+	doc := []byte(`<settings>
+  <proxies/>
+</settings>`)
+
+	result, err := AddServerEntry(doc, Server{ID: "nexus", Username: "admin", Password: "secret"})
+
+	assert.NoError(t, err)
+	assert.True(t, strings.Index(string(result), "<servers>") < strings.Index(string(result), "<proxies/>"))
+}
+
+func TestAddServerEntryFallsBackThroughOfflineUsePluginRegistryInteractiveModeLocalRepository(t *testing.T) {
+	cases := []string{
+		"<settings>\n  <offline>false</offline>\n</settings>",
+		"<settings>\n  <usePluginRegistry>false</usePluginRegistry>\n</settings>",
+		"<settings>\n  <interactiveMode>true</interactiveMode>\n</settings>",
+		"<settings>\n  <localRepository>/home/user/.m2/repository</localRepository>\n</settings>",
+	}
+
+	for _, doc := range cases {
+		result, err := AddServerEntry([]byte(doc), Server{ID: "nexus", Username: "admin", Password: "secret"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, string(result), "<id>nexus</id>")
+	}
+}
+
+func TestAddServerEntryOnMinimalSettingsFallsBackToSettingsClose(t *testing.T) {
+	doc := []byte(`<settings></settings>`)
+
+	result, err := AddServerEntry(doc, Server{ID: "nexus", Username: "admin", Password: "secret"})
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(result), "<id>nexus</id>")
+}
+
+func TestAddServerEntryErrorsWithoutAnyFallbackAnchor(t *testing.T) {
+	doc := []byte(`<settings/>`)
+
+	_, err := AddServerEntry(doc, Server{ID: "nexus", Username: "admin", Password: "secret"})
+
+	assert.Error(t, err)
+}
+
+func TestAddMirrorEntry(t *testing.T) {
+	doc := []byte(`<settings></settings>`)
+
+	result, err := AddMirrorEntry(doc, Mirror{ID: "central-mirror", MirrorOf: "*", URL: "https://repo.example.org/maven"})
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(result), "<mirrorOf>*</mirrorOf>")
+	assert.Contains(t, string(result), "<url>https://repo.example.org/maven</url>")
+}
+
+func TestAddProfileEntryAddsAndActivatesProfile(t *testing.T) {
+	doc := []byte(`<settings></settings>`)
+
+	result, err := AddProfileEntry(doc, Profile{
+		ID:           "resolver",
+		Repositories: []Repository{{ID: "resolver", URL: "https://repo.example.org/maven"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(result), "<id>resolver</id>")
+	assert.Contains(t, string(result), "<activeProfile>resolver</activeProfile>")
+}
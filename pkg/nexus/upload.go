@@ -0,0 +1,205 @@
+package nexus
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/SAP/jenkins-library/pkg/log"
+)
+
+// Coordinate identifies a single Maven artifact file to be uploaded to Nexus.
+type Coordinate struct {
+	GroupID    string
+	ArtifactID string
+	Version    string
+	Packaging  string
+	Classifier string
+	File       string
+}
+
+// IsSnapshot returns true if the coordinate's version is a Maven SNAPSHOT version.
+// Nexus's REST upload endpoints reject uploads of SNAPSHOT versions into hosted
+// snapshot repositories, so callers typically need to special-case this.
+func (c Coordinate) IsSnapshot() bool {
+	return strings.HasSuffix(c.Version, "-SNAPSHOT")
+}
+
+// ClientOptions configure a Client for talking to a Nexus Repository Manager instance.
+type ClientOptions struct {
+	// Version is the Nexus Repository Manager major version, "nexus2" or "nexus3".
+	Version string
+	// BaseURL is the Nexus base URL, e.g. "http://nexus.example.org:8081".
+	BaseURL string
+	// Repository is the name (nexus3) or id (nexus2) of the target repository.
+	Repository string
+	Username   string
+	Password   string
+}
+
+// Client uploads Maven artifacts directly to a Nexus Repository Manager via its REST API.
+// This allows publishing artifacts without a local Maven installation and without having
+// to generate a temporary settings.xml.
+type Client struct {
+	options    ClientOptions
+	httpClient *piperhttp.Client
+}
+
+// NewClient creates a Client ready to upload artifacts according to options.
+func NewClient(options ClientOptions) *Client {
+	httpClient := &piperhttp.Client{}
+	httpClient.SetOptions(piperhttp.ClientOptions{
+		Username: options.Username,
+		Password: options.Password,
+	})
+	return &Client{options: options, httpClient: httpClient}
+}
+
+// Upload pushes the artifact described by coordinate to Nexus, using the REST endpoint
+// appropriate for the configured Nexus version. It returns an error without attempting
+// the upload if coordinate is a SNAPSHOT version, since Nexus's REST upload endpoints
+// reject those for hosted snapshot repositories; callers should fall back to the
+// Maven-based upload in pkg/maven in that case.
+func (c *Client) Upload(coordinate Coordinate) error {
+	if coordinate.IsSnapshot() {
+		return fmt.Errorf("nexus REST upload does not support SNAPSHOT version '%s', use the maven upload mode instead", coordinate.Version)
+	}
+
+	exists, err := fileExists(coordinate.File)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("artifact file '%s' does not exist", coordinate.File)
+	}
+
+	switch c.options.Version {
+	case "nexus2":
+		return c.uploadNexus2(coordinate)
+	case "nexus3":
+		return c.uploadNexus3(coordinate)
+	default:
+		return fmt.Errorf("unsupported nexus version '%s', must be 'nexus2' or 'nexus3'", c.options.Version)
+	}
+}
+
+// uploadNexus2 uploads coordinate via the Nexus 2 "upload artifact" servlet,
+// POSTing a multipart/form-data request to /service/local/artifact/maven/content.
+func (c *Client) uploadNexus2(coordinate Coordinate) error {
+	body, contentType, err := multipartBody(func(writer *multipart.Writer) error {
+		fields := map[string]string{
+			"r": c.options.Repository,
+			"g": coordinate.GroupID,
+			"a": coordinate.ArtifactID,
+			"v": coordinate.Version,
+			"p": coordinate.Packaging,
+		}
+		if coordinate.Classifier != "" {
+			fields["c"] = coordinate.Classifier
+		}
+		for name, value := range fields {
+			if err := writer.WriteField(name, value); err != nil {
+				return err
+			}
+		}
+		return writeFilePart(writer, "file", coordinate.File)
+	})
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimSuffix(c.options.BaseURL, "/") + "/service/local/artifact/maven/content"
+	return c.post(url, body, contentType)
+}
+
+// uploadNexus3 uploads coordinate via the Nexus 3 component upload API,
+// POSTing a multipart/form-data request to /service/rest/v1/components.
+func (c *Client) uploadNexus3(coordinate Coordinate) error {
+	body, contentType, err := multipartBody(func(writer *multipart.Writer) error {
+		fields := map[string]string{
+			"maven2.groupId":          coordinate.GroupID,
+			"maven2.artifactId":       coordinate.ArtifactID,
+			"maven2.version":          coordinate.Version,
+			"maven2.asset1.extension": coordinate.Packaging,
+		}
+		if coordinate.Classifier != "" {
+			fields["maven2.asset1.classifier"] = coordinate.Classifier
+		}
+		for name, value := range fields {
+			if err := writer.WriteField(name, value); err != nil {
+				return err
+			}
+		}
+		return writeFilePart(writer, "maven2.asset1", coordinate.File)
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/service/rest/v1/components?repository=%s",
+		strings.TrimSuffix(c.options.BaseURL, "/"), c.options.Repository)
+	return c.post(url, body, contentType)
+}
+
+func (c *Client) post(url string, body io.Reader, contentType string) error {
+	header := http.Header{}
+	header.Set("Content-Type", contentType)
+
+	response, err := c.httpClient.SendRequest(http.MethodPost, url, body, header, nil)
+	if err != nil {
+		return fmt.Errorf("failed to upload artifact to '%s': %w", url, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("failed to upload artifact to '%s', server returned status %d", url, response.StatusCode)
+	}
+
+	log.Entry().Infof("Successfully uploaded artifact to '%s'", url)
+	return nil
+}
+
+func multipartBody(writeFields func(writer *multipart.Writer) error) (io.Reader, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	if err := writeFields(writer); err != nil {
+		return nil, "", err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return body, writer.FormDataContentType(), nil
+}
+
+func writeFilePart(writer *multipart.Writer, fieldName, filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open artifact file '%s': %w", filePath, err)
+	}
+	defer file.Close()
+
+	part, err := writer.CreateFormFile(fieldName, filepath.Base(filePath))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, file)
+	return err
+}
+
+func fileExists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}